@@ -0,0 +1,154 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBackfillBatchSize is the number of blocks accumulated before a
+// single Store.SaveBlocks call is made, analogous to the accumulator used
+// by Prysm's initial-sync block processing.
+const DefaultBackfillBatchSize = 32
+
+// BackfillProvider fetches historical blocks and proposer duties for a
+// given epoch so that Backfill can hydrate the container from a beacon
+// node without depending on the live event stream.
+type BackfillProvider interface {
+	FetchBlocks(ctx context.Context, epoch phase0.Epoch) ([]*spec.VersionedSignedBeaconBlock, error)
+	FetchProposerDuties(ctx context.Context, epoch phase0.Epoch) ([]*v1.ProposerDuty, error)
+}
+
+// Backfill walks epochs in reverse from fromEpoch down to toEpoch
+// (inclusive), hydrating each one from provider. It is safe to run
+// concurrently with the live ticker/currentSlotLoop goroutines.
+func (c *Container) Backfill(ctx context.Context, provider BackfillProvider, fromEpoch, toEpoch phase0.Epoch) error {
+	if provider == nil {
+		return errors.New("backfill provider is nil")
+	}
+
+	if fromEpoch < toEpoch {
+		return fmt.Errorf("fromEpoch %d must be >= toEpoch %d", fromEpoch, toEpoch)
+	}
+
+	for epoch := fromEpoch; ; epoch-- {
+		if err := c.backfillEpoch(ctx, provider, epoch); err != nil {
+			return fmt.Errorf("failed to backfill epoch %d: %w", epoch, err)
+		}
+
+		if epoch == toEpoch {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (c *Container) backfillEpoch(ctx context.Context, provider BackfillProvider, epochNumber phase0.Epoch) error {
+	epoch, err := c.getOrCreateEpoch(ctx, epochNumber)
+	if err != nil {
+		return err
+	}
+
+	duties, err := provider.FetchProposerDuties(ctx, epochNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch proposer duties: %w", err)
+	}
+
+	if err := c.SetProposerDuties(ctx, epochNumber, duties); err != nil {
+		return fmt.Errorf("failed to set proposer duties: %w", err)
+	}
+
+	blocks, err := provider.FetchBlocks(ctx, epochNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch blocks: %w", err)
+	}
+
+	batch := make([]*BlockRef, 0, DefaultBackfillBatchSize)
+	saved := 0
+
+	for _, block := range blocks {
+		// Unlike AddBeaconBlock, we deliberately skip the per-block log
+		// line here - logging every block in a multi-epoch backfill would
+		// drown out everything else.
+		slotNumber, err := block.Slot()
+		if err != nil {
+			c.log.WithError(err).Warn("Failed to get slot from backfilled block")
+
+			continue
+		}
+
+		// Drive the clock to this slot as history is replayed, so the same
+		// epoch/slot change callbacks fire for backfilled data as for live
+		// operation.
+		if err := c.AdvanceTo(ctx, slotNumber); err != nil {
+			c.log.WithError(err).WithField("slot", slotNumber).Warn("Failed to advance clock for backfilled slot")
+		}
+
+		if err := epoch.AddBlock(block, time.Time{}); err != nil {
+			c.log.WithError(err).WithField("slot", slotNumber).Warn("Failed to add backfilled block")
+
+			continue
+		}
+
+		root, err := block.Root()
+		if err != nil {
+			c.log.WithError(err).WithField("slot", slotNumber).Warn("Failed to calculate backfilled block root")
+
+			continue
+		}
+
+		var proposerIndex phase0.ValidatorIndex
+
+		if slot, slotErr := epoch.GetSlot(slotNumber); slotErr == nil {
+			if duty, dutyErr := slot.ProposerDuty(); dutyErr == nil {
+				proposerIndex = duty.ValidatorIndex
+			} else {
+				c.log.WithError(dutyErr).WithField("slot", slotNumber).Warn("Failed to get slot proposer for backfilled block")
+			}
+		} else {
+			c.log.WithError(slotErr).WithField("slot", slotNumber).Warn("Failed to get slot for backfilled block")
+		}
+
+		batch = append(batch, &BlockRef{
+			Epoch:         epochNumber,
+			Slot:          slotNumber,
+			Root:          root,
+			ProposerIndex: proposerIndex,
+			SeenAt:        time.Time{},
+		})
+
+		if len(batch) >= DefaultBackfillBatchSize {
+			if err := c.store.SaveBlocks(ctx, batch); err != nil {
+				return fmt.Errorf("failed to save block batch: %w", err)
+			}
+
+			saved += len(batch)
+			c.publishBackfillProgress(ctx, epochNumber, len(batch), saved)
+
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := c.store.SaveBlocks(ctx, batch); err != nil {
+			return fmt.Errorf("failed to save block batch: %w", err)
+		}
+
+		saved += len(batch)
+		c.publishBackfillProgress(ctx, epochNumber, len(batch), saved)
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"epoch":  epochNumber,
+		"blocks": len(blocks),
+	}).Info("Backfilled epoch")
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type fakeBackfillPublisher struct {
+	noopPublisher
+
+	progress []BackfillProgressEvent
+}
+
+func (f *fakeBackfillPublisher) PublishBackfillProgress(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) error {
+	f.progress = append(f.progress, BackfillProgressEvent{Epoch: epoch, BlocksSaved: blocksSaved, BlocksTotal: blocksTotal})
+
+	return nil
+}
+
+func TestPublishBackfillProgressNotifiesCallbacksAndPublisher(t *testing.T) {
+	publisher := &fakeBackfillPublisher{}
+
+	var callbackEvents []BackfillProgressEvent
+
+	c := &Container{
+		log:       discardTestLogger(),
+		publisher: publisher,
+	}
+
+	c.OnBackfillProgress(func(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) error {
+		callbackEvents = append(callbackEvents, BackfillProgressEvent{Epoch: epoch, BlocksSaved: blocksSaved, BlocksTotal: blocksTotal})
+
+		return nil
+	})
+
+	// Simulate two batches the way backfillEpoch fires them: one full
+	// DefaultBackfillBatchSize batch, then a smaller trailing batch.
+	c.publishBackfillProgress(context.Background(), 5, DefaultBackfillBatchSize, DefaultBackfillBatchSize)
+	c.publishBackfillProgress(context.Background(), 5, 7, DefaultBackfillBatchSize+7)
+
+	want := []BackfillProgressEvent{
+		{Epoch: 5, BlocksSaved: DefaultBackfillBatchSize, BlocksTotal: DefaultBackfillBatchSize},
+		{Epoch: 5, BlocksSaved: 7, BlocksTotal: DefaultBackfillBatchSize + 7},
+	}
+
+	if len(callbackEvents) != len(want) {
+		t.Fatalf("callback fired %d times, want %d", len(callbackEvents), len(want))
+	}
+
+	if len(publisher.progress) != len(want) {
+		t.Fatalf("publisher notified %d times, want %d", len(publisher.progress), len(want))
+	}
+
+	for i, event := range want {
+		if callbackEvents[i] != event {
+			t.Errorf("callback event %d = %+v, want %+v", i, callbackEvents[i], event)
+		}
+
+		if publisher.progress[i] != event {
+			t.Errorf("publisher event %d = %+v, want %+v", i, publisher.progress[i], event)
+		}
+	}
+}
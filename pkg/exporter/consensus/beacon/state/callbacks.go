@@ -0,0 +1,117 @@
+package state
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// OnEpochChanged registers a callback that is invoked whenever the
+// container's current epoch changes.
+func (c *Container) OnEpochChanged(cb func(ctx context.Context, epoch phase0.Epoch) error) {
+	c.callbacksEpochChanged = append(c.callbacksEpochChanged, cb)
+}
+
+// OnSlotChanged registers a callback that is invoked whenever the
+// container's current slot changes.
+func (c *Container) OnSlotChanged(cb func(ctx context.Context, slot phase0.Slot) error) {
+	c.callbacksSlotChanged = append(c.callbacksSlotChanged, cb)
+}
+
+// OnEpochSlotChanged registers a callback that is invoked whenever either
+// the current epoch or slot changes.
+func (c *Container) OnEpochSlotChanged(cb func(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) error) {
+	c.callbacksEpochSlotChanged = append(c.callbacksEpochSlotChanged, cb)
+}
+
+// OnBlockInserted registers a callback that is invoked whenever a beacon
+// block is inserted into the container.
+func (c *Container) OnBlockInserted(cb func(ctx context.Context, epoch phase0.Epoch, slot Slot) error) {
+	c.callbacksBlockInserted = append(c.callbacksBlockInserted, cb)
+}
+
+// OnBackfillProgress registers a callback that is invoked once per batch
+// the backfill worker saves.
+func (c *Container) OnBackfillProgress(cb func(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) error) {
+	c.callbacksBackfillProgress = append(c.callbacksBackfillProgress, cb)
+}
+
+func (c *Container) publishEpochChanged(ctx context.Context, epoch phase0.Epoch) {
+	for _, cb := range c.callbacksEpochChanged {
+		if err := cb(ctx, epoch); err != nil {
+			c.log.WithError(err).Warn("Failed to execute epoch changed callback")
+		}
+	}
+
+	if c.publisher == nil {
+		return
+	}
+
+	if err := c.publisher.PublishEpochChanged(ctx, epoch); err != nil {
+		c.log.WithError(err).Warn("Failed to publish epoch changed event")
+	}
+}
+
+func (c *Container) publishSlotChanged(ctx context.Context, slot phase0.Slot) {
+	for _, cb := range c.callbacksSlotChanged {
+		if err := cb(ctx, slot); err != nil {
+			c.log.WithError(err).Warn("Failed to execute slot changed callback")
+		}
+	}
+
+	if c.publisher == nil {
+		return
+	}
+
+	if err := c.publisher.PublishSlotChanged(ctx, slot); err != nil {
+		c.log.WithError(err).Warn("Failed to publish slot changed event")
+	}
+}
+
+func (c *Container) publishEpochSlotChanged(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) {
+	for _, cb := range c.callbacksEpochSlotChanged {
+		if err := cb(ctx, epoch, slot); err != nil {
+			c.log.WithError(err).Warn("Failed to execute epoch/slot changed callback")
+		}
+	}
+
+	if c.publisher == nil {
+		return
+	}
+
+	if err := c.publisher.PublishEpochSlotChanged(ctx, epoch, slot); err != nil {
+		c.log.WithError(err).Warn("Failed to publish epoch/slot changed event")
+	}
+}
+
+func (c *Container) publishBlockInserted(ctx context.Context, epoch phase0.Epoch, slot Slot) {
+	for _, cb := range c.callbacksBlockInserted {
+		if err := cb(ctx, epoch, slot); err != nil {
+			c.log.WithError(err).Warn("Failed to execute block inserted callback")
+		}
+	}
+
+	if c.publisher == nil {
+		return
+	}
+
+	if err := c.publisher.PublishBlockInserted(ctx, epoch, slot); err != nil {
+		c.log.WithError(err).Warn("Failed to publish block inserted event")
+	}
+}
+
+func (c *Container) publishBackfillProgress(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) {
+	for _, cb := range c.callbacksBackfillProgress {
+		if err := cb(ctx, epoch, blocksSaved, blocksTotal); err != nil {
+			c.log.WithError(err).Warn("Failed to execute backfill progress callback")
+		}
+	}
+
+	if c.publisher == nil {
+		return
+	}
+
+	if err := c.publisher.PublishBackfillProgress(ctx, epoch, blocksSaved, blocksTotal); err != nil {
+		c.log.WithError(err).Warn("Failed to publish backfill progress event")
+	}
+}
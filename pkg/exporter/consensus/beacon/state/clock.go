@@ -0,0 +1,107 @@
+package state
+
+import (
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Clock abstracts away the wall-clock arithmetic the container uses to
+// derive the current epoch/slot, so that it can be driven from historical
+// data or tests instead of time.Since(genesis).
+type Clock interface {
+	// Now returns the clock's current time.
+	Now() time.Time
+	// SlotAt returns the slot that contains t.
+	SlotAt(t time.Time) phase0.Slot
+	// EpochAt returns the epoch that contains t.
+	EpochAt(t time.Time) phase0.Epoch
+	// SlotStart returns the time at which the given slot starts.
+	SlotStart(slot phase0.Slot) time.Time
+}
+
+// wallClock is the default Clock, deriving everything from genesis time and
+// actual wall-clock time.
+type wallClock struct {
+	spec    *Spec
+	genesis *v1.Genesis
+}
+
+// NewWallClock creates a Clock backed by actual wall-clock time.
+func NewWallClock(sp *Spec, genesis *v1.Genesis) Clock {
+	return &wallClock{spec: sp, genesis: genesis}
+}
+
+func (w *wallClock) Now() time.Time {
+	return time.Now()
+}
+
+func (w *wallClock) SlotAt(t time.Time) phase0.Slot {
+	return phase0.Slot(t.Sub(w.genesis.GenesisTime).Seconds() / w.spec.SecondsPerSlot.Seconds())
+}
+
+func (w *wallClock) EpochAt(t time.Time) phase0.Epoch {
+	return phase0.Epoch(w.SlotAt(t) / phase0.Slot(w.spec.SlotsPerEpoch))
+}
+
+func (w *wallClock) SlotStart(slot phase0.Slot) time.Time {
+	return w.genesis.GenesisTime.Add(w.spec.SecondsPerSlot * time.Duration(slot))
+}
+
+// ManualClock is a Clock whose current time is advanced explicitly, rather
+// than tracking actual wall-clock time. It's used to replay historical data
+// through the container - for backfill and for tests - while still firing
+// the same epoch/slot change callbacks as live operation.
+type ManualClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	spec *Spec
+
+	genesis *v1.Genesis
+}
+
+// NewManualClock creates a ManualClock starting at genesis time.
+func NewManualClock(sp *Spec, genesis *v1.Genesis) *ManualClock {
+	return &ManualClock{
+		spec:    sp,
+		genesis: genesis,
+		now:     genesis.GenesisTime,
+	}
+}
+
+func (m *ManualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+// Set moves the clock to t.
+func (m *ManualClock) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = t
+}
+
+// Advance moves the clock forward by d.
+func (m *ManualClock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+}
+
+func (m *ManualClock) SlotAt(t time.Time) phase0.Slot {
+	return phase0.Slot(t.Sub(m.genesis.GenesisTime).Seconds() / m.spec.SecondsPerSlot.Seconds())
+}
+
+func (m *ManualClock) EpochAt(t time.Time) phase0.Epoch {
+	return phase0.Epoch(m.SlotAt(t) / phase0.Slot(m.spec.SlotsPerEpoch))
+}
+
+func (m *ManualClock) SlotStart(slot phase0.Slot) time.Time {
+	return m.genesis.GenesisTime.Add(m.spec.SecondsPerSlot * time.Duration(slot))
+}
@@ -0,0 +1,51 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func TestManualClockSetAndAdvance(t *testing.T) {
+	genesis := &v1.Genesis{GenesisTime: time.Unix(0, 0).UTC()}
+	sp := &Spec{SlotsPerEpoch: 32, SecondsPerSlot: 12 * time.Second}
+
+	clock := NewManualClock(sp, genesis)
+
+	if got := clock.Now(); !got.Equal(genesis.GenesisTime) {
+		t.Fatalf("Now() = %v, want genesis time %v", got, genesis.GenesisTime)
+	}
+
+	clock.Advance(36 * time.Second)
+
+	if got, want := clock.SlotAt(clock.Now()), phase0.Slot(3); got != want {
+		t.Fatalf("SlotAt(Now()) after Advance(36s) = %d, want %d", got, want)
+	}
+
+	target := genesis.GenesisTime.Add(400 * time.Second)
+	clock.Set(target)
+
+	if got := clock.Now(); !got.Equal(target) {
+		t.Fatalf("Now() after Set() = %v, want %v", got, target)
+	}
+
+	if got, want := clock.EpochAt(clock.Now()), phase0.Epoch(1); got != want {
+		t.Fatalf("EpochAt(Now()) = %d, want %d", got, want)
+	}
+}
+
+func TestManualClockSlotStart(t *testing.T) {
+	genesis := &v1.Genesis{GenesisTime: time.Unix(1700000000, 0).UTC()}
+	sp := &Spec{SlotsPerEpoch: 32, SecondsPerSlot: 12 * time.Second}
+
+	clock := NewManualClock(sp, genesis)
+
+	got := clock.SlotStart(10)
+	want := genesis.GenesisTime.Add(120 * time.Second)
+
+	if !got.Equal(want) {
+		t.Fatalf("SlotStart(10) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+)
+
+// NATSPublisher bridges state container lifecycle events to a NATS
+// JetStream publisher, modelled on the decorated publisher pattern used
+// elsewhere in the ethpandaops tooling. Any `nats.JetStreamContext`
+// works here, including one backed by an embedded NATS server.
+type NATSPublisher struct {
+	log logrus.FieldLogger
+	js  nats.JetStreamContext
+}
+
+// NewNATSPublisher creates a Publisher that publishes to the given
+// JetStream context.
+func NewNATSPublisher(log logrus.FieldLogger, js nats.JetStreamContext) *NATSPublisher {
+	return &NATSPublisher{
+		log: log.WithField("component", "nats_publisher"),
+		js:  js,
+	}
+}
+
+func (n *NATSPublisher) publish(ctx context.Context, subject string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if _, err := n.js.Publish(subject, data, nats.Context(ctx)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *NATSPublisher) PublishEpochChanged(ctx context.Context, epoch phase0.Epoch) error {
+	return n.publish(ctx, SubjectEpochChanged, &EpochChangedEvent{Epoch: epoch})
+}
+
+func (n *NATSPublisher) PublishSlotChanged(ctx context.Context, slot phase0.Slot) error {
+	return n.publish(ctx, SubjectSlotChanged, &SlotChangedEvent{Slot: slot})
+}
+
+func (n *NATSPublisher) PublishEpochSlotChanged(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) error {
+	return n.publish(ctx, SubjectEpochSlotChanged, &EpochSlotChangedEvent{Epoch: epoch, Slot: slot})
+}
+
+func (n *NATSPublisher) PublishBlockInserted(ctx context.Context, epoch phase0.Epoch, slot Slot) error {
+	delay, err := slot.ProposerDelay()
+	if err != nil {
+		n.log.WithError(err).Warn("Failed to get proposer delay for block inserted event")
+	}
+
+	var proposerIndex phase0.ValidatorIndex
+
+	duty, err := slot.ProposerDuty()
+	if err == nil && duty != nil {
+		proposerIndex = duty.ValidatorIndex
+	}
+
+	return n.publish(ctx, SubjectBlockInserted, &BlockInsertedEvent{
+		Epoch:         epoch,
+		Slot:          slot.Number(),
+		ProposerIndex: proposerIndex,
+		ProposerDelay: int64(delay.Seconds()),
+	})
+}
+
+func (n *NATSPublisher) PublishBackfillProgress(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) error {
+	return n.publish(ctx, SubjectBackfillProgress, &BackfillProgressEvent{
+		Epoch:       epoch,
+		BlocksSaved: blocksSaved,
+		BlocksTotal: blocksTotal,
+	})
+}
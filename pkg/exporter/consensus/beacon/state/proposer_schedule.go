@@ -0,0 +1,64 @@
+package state
+
+import (
+	"context"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// indexProposerDuties maintains the validator index -> []slot reverse index
+// used by ProposerSchedule. It replaces whatever was previously indexed for
+// this epoch's slot range before adding the new duties, so re-fetching an
+// epoch's duties doesn't leave stale entries behind.
+func (c *Container) indexProposerDuties(epochNumber phase0.Epoch, duties []*v1.ProposerDuty) {
+	c.proposerScheduleMu.Lock()
+	defer c.proposerScheduleMu.Unlock()
+
+	startSlot := phase0.Slot(epochNumber) * c.spec.SlotsPerEpoch
+	endSlot := startSlot + c.spec.SlotsPerEpoch
+
+	for index, slots := range c.proposerSchedule {
+		remaining := slots[:0]
+
+		for _, slot := range slots {
+			if slot < startSlot || slot >= endSlot {
+				remaining = append(remaining, slot)
+			}
+		}
+
+		if len(remaining) == 0 {
+			delete(c.proposerSchedule, index)
+		} else {
+			c.proposerSchedule[index] = remaining
+		}
+	}
+
+	for _, duty := range duties {
+		c.proposerSchedule[duty.ValidatorIndex] = append(c.proposerSchedule[duty.ValidatorIndex], duty.Slot)
+	}
+}
+
+// ProposerSchedule resolves, in a single round-trip, the upcoming slots each
+// of the given validator indices is due to propose in across the
+// [fromEpoch, toEpoch] window, following the pattern of Prysm's bulk
+// SaveValidatorIndices call rather than looking indices up one at a time.
+func (c *Container) ProposerSchedule(ctx context.Context, indices []phase0.ValidatorIndex, fromEpoch, toEpoch phase0.Epoch) (map[phase0.ValidatorIndex][]phase0.Slot, error) {
+	startSlot := phase0.Slot(fromEpoch) * c.spec.SlotsPerEpoch
+	endSlot := phase0.Slot(toEpoch+1) * c.spec.SlotsPerEpoch
+
+	c.proposerScheduleMu.Lock()
+	defer c.proposerScheduleMu.Unlock()
+
+	schedule := make(map[phase0.ValidatorIndex][]phase0.Slot, len(indices))
+
+	for _, index := range indices {
+		for _, slot := range c.proposerSchedule[index] {
+			if slot >= startSlot && slot < endSlot {
+				schedule[index] = append(schedule[index], slot)
+			}
+		}
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,77 @@
+package state
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func newTestContainer() *Container {
+	return &Container{
+		spec:             &Spec{SlotsPerEpoch: 32, SecondsPerSlot: 12 * time.Second},
+		proposerSchedule: make(map[phase0.ValidatorIndex][]phase0.Slot),
+	}
+}
+
+func TestIndexProposerDutiesReplacesStaleEntriesForTheEpoch(t *testing.T) {
+	c := newTestContainer()
+
+	c.indexProposerDuties(1, []*v1.ProposerDuty{
+		{ValidatorIndex: 10, Slot: 32},
+		{ValidatorIndex: 11, Slot: 40},
+	})
+
+	// Re-indexing epoch 1 should drop the stale slot 40 entry for index 11
+	// rather than appending alongside it.
+	c.indexProposerDuties(1, []*v1.ProposerDuty{
+		{ValidatorIndex: 11, Slot: 41},
+	})
+
+	if got, want := c.proposerSchedule[10], ([]phase0.Slot)(nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("proposerSchedule[10] = %v, want empty (cleared by re-index)", got)
+	}
+
+	if got, want := c.proposerSchedule[11], []phase0.Slot{41}; !reflect.DeepEqual(got, want) {
+		t.Errorf("proposerSchedule[11] = %v, want %v", got, want)
+	}
+}
+
+func TestProposerScheduleBulkLookupAcrossEpochWindow(t *testing.T) {
+	c := newTestContainer()
+
+	c.indexProposerDuties(0, []*v1.ProposerDuty{
+		{ValidatorIndex: 10, Slot: 5},
+		{ValidatorIndex: 20, Slot: 6},
+	})
+	c.indexProposerDuties(1, []*v1.ProposerDuty{
+		{ValidatorIndex: 10, Slot: 40},
+	})
+	c.indexProposerDuties(2, []*v1.ProposerDuty{
+		{ValidatorIndex: 10, Slot: 70},
+	})
+
+	schedule, err := c.ProposerSchedule(context.Background(), []phase0.ValidatorIndex{10, 20}, 0, 1)
+	if err != nil {
+		t.Fatalf("ProposerSchedule() error = %v", err)
+	}
+
+	gotSlots := append([]phase0.Slot{}, schedule[10]...)
+	sort.Slice(gotSlots, func(i, j int) bool { return gotSlots[i] < gotSlots[j] })
+
+	if want := []phase0.Slot{5, 40}; !reflect.DeepEqual(gotSlots, want) {
+		t.Errorf("schedule[10] = %v, want %v (slot 70 from epoch 2 is outside the window)", gotSlots, want)
+	}
+
+	if want := []phase0.Slot{6}; !reflect.DeepEqual(schedule[20], want) {
+		t.Errorf("schedule[20] = %v, want %v", schedule[20], want)
+	}
+
+	if _, ok := schedule[30]; ok {
+		t.Errorf("schedule contains an entry for index 30, which was never given duties")
+	}
+}
@@ -0,0 +1,94 @@
+package state
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Subjects published by the NATS-backed Publisher implementation.
+const (
+	SubjectEpochChanged     = "beacon.epoch.changed"
+	SubjectSlotChanged      = "beacon.slot.changed"
+	SubjectEpochSlotChanged = "beacon.epoch_slot.changed"
+	SubjectBlockInserted    = "beacon.block.inserted"
+	SubjectBackfillProgress = "beacon.backfill.progress"
+)
+
+// Publisher bridges state container lifecycle events to an external event
+// bus. NewContainer defaults to a no-op Publisher when nil is passed, so
+// wiring one up is entirely optional; a bare Container{} literal has a nil
+// Publisher and callers must not publish through it.
+type Publisher interface {
+	// PublishEpochChanged is called whenever the container's current epoch changes.
+	PublishEpochChanged(ctx context.Context, epoch phase0.Epoch) error
+	// PublishSlotChanged is called whenever the container's current slot changes.
+	PublishSlotChanged(ctx context.Context, slot phase0.Slot) error
+	// PublishEpochSlotChanged is called whenever either the current epoch or slot changes.
+	PublishEpochSlotChanged(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) error
+	// PublishBlockInserted is called whenever a beacon block is inserted into the container.
+	PublishBlockInserted(ctx context.Context, epoch phase0.Epoch, slot Slot) error
+	// PublishBackfillProgress is called once per batch saved by the backfill worker.
+	PublishBackfillProgress(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) error
+}
+
+// EpochChangedEvent is the JSON payload published on SubjectEpochChanged.
+type EpochChangedEvent struct {
+	Epoch phase0.Epoch `json:"epoch"`
+}
+
+// SlotChangedEvent is the JSON payload published on SubjectSlotChanged.
+type SlotChangedEvent struct {
+	Slot phase0.Slot `json:"slot"`
+}
+
+// EpochSlotChangedEvent is the JSON payload published on SubjectEpochSlotChanged.
+type EpochSlotChangedEvent struct {
+	Epoch phase0.Epoch `json:"epoch"`
+	Slot  phase0.Slot  `json:"slot"`
+}
+
+// BlockInsertedEvent is the JSON payload published on SubjectBlockInserted.
+type BlockInsertedEvent struct {
+	Epoch         phase0.Epoch          `json:"epoch"`
+	Slot          phase0.Slot           `json:"slot"`
+	ProposerIndex phase0.ValidatorIndex `json:"proposer_index"`
+	ProposerDelay int64                 `json:"proposer_delay_seconds"`
+}
+
+// BackfillProgressEvent is the JSON payload published on
+// SubjectBackfillProgress, once per batch the backfill worker saves.
+type BackfillProgressEvent struct {
+	Epoch       phase0.Epoch `json:"epoch"`
+	BlocksSaved int          `json:"blocks_saved"`
+	BlocksTotal int          `json:"blocks_total"`
+}
+
+// noopPublisher is the default Publisher used when none is configured. It
+// discards every event.
+type noopPublisher struct{}
+
+// NewNoopPublisher creates a Publisher that discards every event.
+func NewNoopPublisher() Publisher {
+	return &noopPublisher{}
+}
+
+func (n *noopPublisher) PublishEpochChanged(ctx context.Context, epoch phase0.Epoch) error {
+	return nil
+}
+
+func (n *noopPublisher) PublishSlotChanged(ctx context.Context, slot phase0.Slot) error {
+	return nil
+}
+
+func (n *noopPublisher) PublishEpochSlotChanged(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) error {
+	return nil
+}
+
+func (n *noopPublisher) PublishBlockInserted(ctx context.Context, epoch phase0.Epoch, slot Slot) error {
+	return nil
+}
+
+func (n *noopPublisher) PublishBackfillProgress(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) error {
+	return nil
+}
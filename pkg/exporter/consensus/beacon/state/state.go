@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -14,10 +15,17 @@ import (
 
 // Container is the state container.
 type Container struct {
-	log     logrus.FieldLogger
-	spec    *Spec
-	genesis *v1.Genesis
-	epochs  Epochs
+	log       logrus.FieldLogger
+	spec      *Spec
+	genesis   *v1.Genesis
+	epochsMu  sync.Mutex
+	epochs    Epochs
+	publisher Publisher
+	store     Store
+	clock     Clock
+
+	proposerScheduleMu sync.Mutex
+	proposerSchedule   map[phase0.ValidatorIndex][]phase0.Slot
 
 	currentEpoch phase0.Epoch
 	currentSlot  phase0.Slot
@@ -26,6 +34,7 @@ type Container struct {
 	callbacksSlotChanged      []func(ctx context.Context, slot phase0.Slot) error
 	callbacksEpochSlotChanged []func(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) error
 	callbacksBlockInserted    []func(ctx context.Context, epoch phase0.Epoch, slot Slot) error
+	callbacksBackfillProgress []func(ctx context.Context, epoch phase0.Epoch, blocksSaved, blocksTotal int) error
 }
 
 const (
@@ -33,18 +42,38 @@ const (
 	SurroundingEpochDistance = 1
 )
 
-// NewContainer creates a new state container instance
-func NewContainer(ctx context.Context, log logrus.FieldLogger, sp *Spec, genesis *v1.Genesis) Container {
+// NewContainer creates a new state container instance. publisher may be nil,
+// in which case lifecycle events are not bridged to an external event bus.
+// store may be nil, in which case an in-memory Store is used and nothing
+// survives a restart. clock may be nil, in which case the container derives
+// the current epoch/slot from actual wall-clock time.
+func NewContainer(ctx context.Context, log logrus.FieldLogger, sp *Spec, genesis *v1.Genesis, publisher Publisher, store Store, clock Clock) Container {
+	if publisher == nil {
+		publisher = NewNoopPublisher()
+	}
+
+	if store == nil {
+		store = NewMemoryStore()
+	}
+
+	if clock == nil {
+		clock = NewWallClock(sp, genesis)
+	}
+
 	return Container{
 		log:  log,
 		spec: sp,
 
-		genesis: genesis,
+		genesis:   genesis,
+		publisher: publisher,
+		store:     store,
+		clock:     clock,
 
 		currentEpoch: 0,
 		currentSlot:  0,
 
-		epochs: NewEpochs(sp, genesis),
+		epochs:           NewEpochs(sp, genesis),
+		proposerSchedule: make(map[phase0.ValidatorIndex][]phase0.Slot),
 	}
 }
 
@@ -89,7 +118,7 @@ func (c *Container) currentSlotLoop(ctx context.Context) error {
 	for {
 		currentSlot := c.currentSlot
 
-		nextSlotStartsAt := c.genesis.GenesisTime.Add(c.spec.SecondsPerSlot * time.Duration(currentSlot+1))
+		nextSlotStartsAt := c.clock.SlotStart(currentSlot + 1)
 
 		select {
 		case <-ctx.Done():
@@ -126,14 +155,7 @@ func (c *Container) AddBeaconBlock(ctx context.Context, beaconBlock *spec.Versio
 
 	epochNumber := c.calculateEpochFromSlot(slotNumber)
 
-	if exists := c.epochs.Exists(epochNumber); !exists {
-		if _, err = c.createEpoch(ctx, epochNumber); err != nil {
-			return err
-		}
-	}
-
-	// Get the epoch
-	epoch, err := c.epochs.GetEpoch(epochNumber)
+	epoch, err := c.getOrCreateEpoch(ctx, epochNumber)
 	if err != nil {
 		return err
 	}
@@ -156,9 +178,12 @@ func (c *Container) AddBeaconBlock(ctx context.Context, beaconBlock *spec.Versio
 
 	proposer := "unknown"
 
+	var proposerIndex phase0.ValidatorIndex
+
 	proposerDuty, err := slot.ProposerDuty()
 	if err == nil {
 		proposer = fmt.Sprintf("%v", proposerDuty.ValidatorIndex)
+		proposerIndex = proposerDuty.ValidatorIndex
 	} else {
 		c.log.WithError(err).WithField("slot", slot).Warn("Failed to get slot proposer")
 	}
@@ -170,20 +195,71 @@ func (c *Container) AddBeaconBlock(ctx context.Context, beaconBlock *spec.Versio
 		"proposer_index": proposer,
 	}).Info("Inserted beacon block")
 
+	if root, rootErr := beaconBlock.Root(); rootErr == nil {
+		if err := c.store.SaveBlock(ctx, &BlockRef{
+			Epoch:         epochNumber,
+			Slot:          slotNumber,
+			Root:          root,
+			ProposerIndex: proposerIndex,
+			SeenAt:        seenAt,
+		}); err != nil {
+			c.log.WithError(err).Warn("Failed to persist beacon block to store")
+		}
+	} else {
+		c.log.WithError(rootErr).Warn("Failed to calculate beacon block root")
+	}
+
 	c.publishBlockInserted(ctx, epochNumber, *slot)
 
 	return nil
 }
 
+// HandleChainReorgEvent marks the block(s) displaced at event.Slot as
+// orphaned in the configured Store, rather than dropping them, so that
+// historical queries can still distinguish canonical from orphaned blocks.
+// event.Depth blocks are actually affected by the reorg, but event.NewHeadBlock
+// is only a valid canonical reference for event.Slot itself - the container
+// has no header/canonical lookup to resolve which block was truly canonical
+// at any of the earlier slots in the depth window, so those are deliberately
+// left untouched rather than risk orphaning a still-canonical block.
+func (c *Container) HandleChainReorgEvent(ctx context.Context, event *v1.ChainReorgEvent) error {
+	if event == nil {
+		return errors.New("chain reorg event is nil")
+	}
+
+	epoch := c.calculateEpochFromSlot(event.Slot)
+
+	blocks, err := c.store.GetBlocksAtSlot(ctx, epoch, event.Slot)
+	if err != nil {
+		return fmt.Errorf("failed to get blocks at slot %d: %w", event.Slot, err)
+	}
+
+	for _, block := range blocks {
+		if block.Root == event.NewHeadBlock {
+			continue
+		}
+
+		if err := c.store.MarkOrphaned(ctx, event.Slot, block.Root); err != nil {
+			return fmt.Errorf("failed to mark block as orphaned: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetOrphanedBlocks returns the blocks in the given epoch that have been
+// marked orphaned by a chain reorg.
+func (c *Container) GetOrphanedBlocks(ctx context.Context, epochNumber phase0.Epoch) ([]*BlockRef, error) {
+	return c.store.GetOrphanedBlocks(ctx, epochNumber)
+}
+
 func (c *Container) hydrateEpochs(ctx context.Context) error {
 	epoch := c.currentEpoch
 
 	// Ensure the state has +-SurroundingEpochDistance epochs created.
 	for i := epoch - SurroundingEpochDistance; i <= epoch+SurroundingEpochDistance; i++ {
-		if _, err := c.epochs.GetEpoch(i); err != nil {
-			if _, err := c.createEpoch(ctx, i); err != nil {
-				return err
-			}
+		if _, err := c.getOrCreateEpoch(ctx, i); err != nil {
+			return err
 		}
 	}
 
@@ -203,17 +279,32 @@ func (c *Container) getCurrentEpochAndSlot() (phase0.Epoch, phase0.Slot, error)
 		return 0, 0, err
 	}
 
-	// Calculate the current epoch based on genesis time.
-	genesis := c.genesis.GenesisTime
+	now := c.clock.Now()
 
-	currentSlot := phase0.Slot(time.Since(genesis).Seconds() / c.spec.SecondsPerSlot.Seconds())
-	currentEpoch := phase0.Epoch(currentSlot / c.spec.SlotsPerEpoch)
+	return c.clock.EpochAt(now), c.clock.SlotAt(now), nil
+}
 
-	return currentEpoch, currentSlot, nil
+// AdvanceTo moves the container's clock to the start of the given slot and
+// fires the same epoch/slot change callbacks that live operation would,
+// allowing replayed history to drive the container identically to the live
+// ticker/currentSlotLoop goroutines. It requires the container to have been
+// constructed with a *ManualClock.
+func (c *Container) AdvanceTo(ctx context.Context, slot phase0.Slot) error {
+	manual, ok := c.clock.(*ManualClock)
+	if !ok {
+		return errors.New("AdvanceTo requires a container configured with a ManualClock")
+	}
+
+	manual.Set(c.clock.SlotStart(slot))
+
+	return c.checkForNewCurrentEpochAndSlot(ctx)
 }
 
 func (c *Container) SetProposerDuties(ctx context.Context, epochNumber phase0.Epoch, duties []*v1.ProposerDuty) error {
+	c.epochsMu.Lock()
 	epoch, err := c.epochs.GetEpoch(epochNumber)
+	c.epochsMu.Unlock()
+
 	if err != nil {
 		return err
 	}
@@ -222,9 +313,12 @@ func (c *Container) SetProposerDuties(ctx context.Context, epochNumber phase0.Ep
 		return err
 	}
 
+	c.indexProposerDuties(epochNumber, duties)
+
 	return nil
 }
 
+// createEpoch creates the given epoch. Callers must hold epochsMu.
 func (c *Container) createEpoch(ctx context.Context, epochNumber phase0.Epoch) (*Epoch, error) {
 	epoch, err := c.epochs.NewInitializedEpoch(epochNumber)
 	if err != nil {
@@ -234,6 +328,21 @@ func (c *Container) createEpoch(ctx context.Context, epochNumber phase0.Epoch) (
 	return epoch, nil
 }
 
+// getOrCreateEpoch returns the epoch for the given epoch number, creating it
+// first if it doesn't already exist. It is safe for concurrent use, which
+// matters once the backfill worker and the live ticker/currentSlotLoop
+// goroutines can both be touching epochs at the same time.
+func (c *Container) getOrCreateEpoch(ctx context.Context, epochNumber phase0.Epoch) (*Epoch, error) {
+	c.epochsMu.Lock()
+	defer c.epochsMu.Unlock()
+
+	if exists := c.epochs.Exists(epochNumber); !exists {
+		return c.createEpoch(ctx, epochNumber)
+	}
+
+	return c.epochs.GetEpoch(epochNumber)
+}
+
 func (c *Container) checkForNewCurrentEpochAndSlot(ctx context.Context) error {
 	epoch, slot, err := c.getCurrentEpochAndSlot()
 	if err != nil {
@@ -272,7 +381,10 @@ func (c *Container) checkForNewCurrentEpochAndSlot(ctx context.Context) error {
 
 // GetSlot returns the slot for the given slot number.
 func (c *Container) GetSlot(ctx context.Context, slotNumber phase0.Slot) (*Slot, error) {
+	c.epochsMu.Lock()
 	epoch, err := c.epochs.GetEpoch(c.calculateEpochFromSlot(slotNumber))
+	c.epochsMu.Unlock()
+
 	if err != nil {
 		return nil, err
 	}
@@ -286,9 +398,22 @@ func (c *Container) calculateEpochFromSlot(slotNumber phase0.Slot) phase0.Epoch
 
 // GetEpoch returns the epoch for the given epoch number.
 func (c *Container) GetEpoch(ctx context.Context, epochNumber phase0.Epoch) (*Epoch, error) {
+	c.epochsMu.Lock()
+	defer c.epochsMu.Unlock()
+
 	return c.epochs.GetEpoch(epochNumber)
 }
 
+// DeleteEpoch removes the epoch from the container's in-memory working set.
+// When a Store is configured, the epoch is archived rather than discarded so
+// that it can still be queried later.
 func (c *Container) DeleteEpoch(ctx context.Context, epochNumber phase0.Epoch) error {
+	if err := c.store.ArchiveEpoch(ctx, epochNumber); err != nil {
+		c.log.WithError(err).WithField("epoch", epochNumber).Warn("Failed to archive epoch")
+	}
+
+	c.epochsMu.Lock()
+	defer c.epochsMu.Unlock()
+
 	return c.epochs.RemoveEpoch(epochNumber)
 }
@@ -0,0 +1,143 @@
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// BlockRef is a reference to a single beacon block as persisted by a Store.
+// It mirrors the minimal set of fields the container needs to survive a
+// restart: which slot/root the block belongs to, who proposed it, when it
+// was seen and whether it has since been orphaned by a reorg.
+type BlockRef struct {
+	Epoch         phase0.Epoch
+	Slot          phase0.Slot
+	Root          phase0.Root
+	ProposerIndex phase0.ValidatorIndex
+	SeenAt        time.Time
+	Orphaned      bool
+}
+
+// Store persists per-slot block references (root, proposer index, seen-at
+// time and orphaned status) outside the container's in-memory Epochs.
+// Proposer duties are not stored, and the container does not reload its
+// Epochs/Slots from a Store. Implementations should treat
+// SaveBlock as an upsert keyed on (slot, root) so that re-processing the
+// same block is idempotent.
+type Store interface {
+	// SaveBlock persists a single block reference.
+	SaveBlock(ctx context.Context, block *BlockRef) error
+	// SaveBlocks persists a batch of block references in one round-trip.
+	// Used by the backfill path to avoid one insert per block.
+	SaveBlocks(ctx context.Context, blocks []*BlockRef) error
+	// MarkOrphaned marks the block at the given slot/root as orphaned,
+	// rather than deleting it, so historical queries can still see it.
+	MarkOrphaned(ctx context.Context, slot phase0.Slot, root phase0.Root) error
+	// GetBlocksAtSlot returns every known block reference for the given
+	// epoch/slot, canonical or already orphaned. Used to find every block
+	// competing at a chain reorg's slot, not just the single root reported
+	// as the old head.
+	GetBlocksAtSlot(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) ([]*BlockRef, error)
+	// GetOrphanedBlocks returns every block reference in the given epoch
+	// that has been marked orphaned.
+	GetOrphanedBlocks(ctx context.Context, epoch phase0.Epoch) ([]*BlockRef, error)
+	// ArchiveEpoch is called instead of discarding an epoch outright when
+	// the container's in-memory view of it is removed.
+	ArchiveEpoch(ctx context.Context, epoch phase0.Epoch) error
+}
+
+// memoryStore is the default Store used when none is configured. It keeps
+// everything in memory for the lifetime of the process, so it offers no
+// restart durability on its own but gives the container a consistent place
+// to track orphaned blocks.
+type memoryStore struct {
+	mu     sync.Mutex
+	blocks map[phase0.Epoch]map[phase0.Root]*BlockRef
+}
+
+// NewMemoryStore creates an in-memory Store.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		blocks: make(map[phase0.Epoch]map[phase0.Root]*BlockRef),
+	}
+}
+
+func (s *memoryStore) SaveBlock(ctx context.Context, block *BlockRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.blocks[block.Epoch]; !exists {
+		s.blocks[block.Epoch] = make(map[phase0.Root]*BlockRef)
+	}
+
+	s.blocks[block.Epoch][block.Root] = block
+
+	return nil
+}
+
+func (s *memoryStore) SaveBlocks(ctx context.Context, blocks []*BlockRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, block := range blocks {
+		if _, exists := s.blocks[block.Epoch]; !exists {
+			s.blocks[block.Epoch] = make(map[phase0.Root]*BlockRef)
+		}
+
+		s.blocks[block.Epoch][block.Root] = block
+	}
+
+	return nil
+}
+
+func (s *memoryStore) MarkOrphaned(ctx context.Context, slot phase0.Slot, root phase0.Root) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, byRoot := range s.blocks {
+		if block, exists := byRoot[root]; exists && block.Slot == slot {
+			block.Orphaned = true
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore) GetBlocksAtSlot(ctx context.Context, epoch phase0.Epoch, slot phase0.Slot) ([]*BlockRef, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blocks := []*BlockRef{}
+
+	for _, block := range s.blocks[epoch] {
+		if block.Slot == slot {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+func (s *memoryStore) GetOrphanedBlocks(ctx context.Context, epoch phase0.Epoch) ([]*BlockRef, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orphaned := []*BlockRef{}
+
+	for _, block := range s.blocks[epoch] {
+		if block.Orphaned {
+			orphaned = append(orphaned, block)
+		}
+	}
+
+	return orphaned, nil
+}
+
+func (s *memoryStore) ArchiveEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	// Blocks are already retained in memory; archiving an epoch is a no-op
+	// beyond removing it from the container's live working set.
+	return nil
+}
@@ -0,0 +1,167 @@
+package state
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+)
+
+// discardTestLogger returns a logrus.FieldLogger that discards everything,
+// for tests that need a Container but don't care about its log output.
+func discardTestLogger() logrus.FieldLogger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	return log
+}
+
+func TestMemoryStoreSaveAndGetBlocksAtSlot(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	root1 := phase0.Root{0x01}
+	root2 := phase0.Root{0x02}
+
+	if err := store.SaveBlock(ctx, &BlockRef{Epoch: 1, Slot: 32, Root: root1}); err != nil {
+		t.Fatalf("SaveBlock() error = %v", err)
+	}
+
+	if err := store.SaveBlocks(ctx, []*BlockRef{
+		{Epoch: 1, Slot: 32, Root: root2},
+		{Epoch: 1, Slot: 33, Root: phase0.Root{0x03}},
+	}); err != nil {
+		t.Fatalf("SaveBlocks() error = %v", err)
+	}
+
+	blocks, err := store.GetBlocksAtSlot(ctx, 1, 32)
+	if err != nil {
+		t.Fatalf("GetBlocksAtSlot() error = %v", err)
+	}
+
+	if len(blocks) != 2 {
+		t.Fatalf("GetBlocksAtSlot() returned %d blocks, want 2", len(blocks))
+	}
+}
+
+func TestMemoryStoreMarkOrphanedRequiresExactMatch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	root := phase0.Root{0x01}
+
+	if err := store.SaveBlock(ctx, &BlockRef{Epoch: 1, Slot: 32, Root: root}); err != nil {
+		t.Fatalf("SaveBlock() error = %v", err)
+	}
+
+	// A mismatched slot should mark nothing.
+	if err := store.MarkOrphaned(ctx, 33, root); err != nil {
+		t.Fatalf("MarkOrphaned() error = %v", err)
+	}
+
+	orphaned, err := store.GetOrphanedBlocks(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetOrphanedBlocks() error = %v", err)
+	}
+
+	if len(orphaned) != 0 {
+		t.Fatalf("GetOrphanedBlocks() = %d, want 0 before a matching MarkOrphaned call", len(orphaned))
+	}
+
+	if err := store.MarkOrphaned(ctx, 32, root); err != nil {
+		t.Fatalf("MarkOrphaned() error = %v", err)
+	}
+
+	orphaned, err = store.GetOrphanedBlocks(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetOrphanedBlocks() error = %v", err)
+	}
+
+	if len(orphaned) != 1 || orphaned[0].Root != root {
+		t.Fatalf("GetOrphanedBlocks() = %+v, want the block at slot 32 marked orphaned", orphaned)
+	}
+}
+
+func TestMemoryStoreArchiveEpochIsANoop(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.SaveBlock(ctx, &BlockRef{Epoch: 1, Slot: 32, Root: phase0.Root{0x01}}); err != nil {
+		t.Fatalf("SaveBlock() error = %v", err)
+	}
+
+	if err := store.ArchiveEpoch(ctx, 1); err != nil {
+		t.Fatalf("ArchiveEpoch() error = %v", err)
+	}
+
+	blocks, err := store.GetBlocksAtSlot(ctx, 1, 32)
+	if err != nil {
+		t.Fatalf("GetBlocksAtSlot() error = %v", err)
+	}
+
+	if len(blocks) != 1 {
+		t.Fatalf("GetBlocksAtSlot() = %d blocks after ArchiveEpoch, want 1 (archiving shouldn't discard data)", len(blocks))
+	}
+}
+
+func TestContainerHandleChainReorgEventOrphansCompetingBlocksAtSlot(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	oldHead := phase0.Root{0x12}
+	newHead := phase0.Root{0x99}
+	earlierRoot := phase0.Root{0x10}
+
+	// Two competing blocks at the reorg slot, plus one at an earlier slot
+	// within the reported depth window that the event cannot vouch for.
+	if err := store.SaveBlock(ctx, &BlockRef{Epoch: 0, Slot: 31, Root: oldHead}); err != nil {
+		t.Fatalf("SaveBlock() error = %v", err)
+	}
+
+	if err := store.SaveBlock(ctx, &BlockRef{Epoch: 0, Slot: 31, Root: newHead}); err != nil {
+		t.Fatalf("SaveBlock() error = %v", err)
+	}
+
+	if err := store.SaveBlock(ctx, &BlockRef{Epoch: 0, Slot: 30, Root: earlierRoot}); err != nil {
+		t.Fatalf("SaveBlock() error = %v", err)
+	}
+
+	c := &Container{
+		log:   discardTestLogger(),
+		spec:  &Spec{SlotsPerEpoch: 32},
+		store: store,
+	}
+
+	event := &v1.ChainReorgEvent{
+		Slot:         31,
+		Depth:        3,
+		OldHeadBlock: oldHead,
+		NewHeadBlock: newHead,
+	}
+
+	if err := c.HandleChainReorgEvent(ctx, event); err != nil {
+		t.Fatalf("HandleChainReorgEvent() error = %v", err)
+	}
+
+	orphaned, err := store.GetOrphanedBlocks(ctx, 0)
+	if err != nil {
+		t.Fatalf("GetOrphanedBlocks() error = %v", err)
+	}
+
+	if len(orphaned) != 1 || orphaned[0].Root != oldHead || orphaned[0].Slot != 31 {
+		t.Fatalf("GetOrphanedBlocks() = %+v, want only the old head at slot 31 orphaned", orphaned)
+	}
+
+	for _, block := range orphaned {
+		if block.Root == newHead {
+			t.Errorf("new head block must not be marked orphaned")
+		}
+
+		if block.Root == earlierRoot {
+			t.Errorf("the block at an earlier slot in the depth window must be left untouched - it has no known canonical root")
+		}
+	}
+}